@@ -5,18 +5,26 @@
 package mop
 
 import (
+	`fmt`
 	`regexp`
 	`strings`
 	`github.com/michaeldv/termbox-go`
 )
 
 type LineEditor struct {
-	command   rune
-	prompt    string
-	cursor    int
-	input     string
-	screen   *Screen
-	quotes   *Quotes
+	command      rune
+	prompt       string
+	cursor       int
+	input        string
+	screen      *Screen
+	quotes      *Quotes
+	panel        bool   // True while a Screen.DrawPanel overlay (e.g. security info) is up, waiting to be dismissed.
+	historyIndex int    // Position in quotes.profile.History while browsing with arrow keys, -1 when not browsing.
+	savedInput   string // self.input as it was before history browsing or reverse-search started.
+	searching    bool   // True while in KeyCtrlR incremental reverse-search mode.
+	searchQuery  string // Substring typed so far in reverse-search mode.
+	searchPos    int    // How many matches to skip from the most recent, bumped by repeated KeyCtrlR.
+	searchLineWidth int // Width of the last reverse-search line drawn on row 3, so a shorter redraw clears what it left behind.
 }
 
 //-----------------------------------------------------------------------------
@@ -29,10 +37,11 @@ func (self *LineEditor) Initialize(screen *Screen, quotes *Quotes) *LineEditor {
 
 //-----------------------------------------------------------------------------
 func (self *LineEditor) Prompt(command rune) *LineEditor {
-	prompts := map[rune]string{'+': `Add tickers: `, '-': `Remove tickers: `}
+	prompts := map[rune]string{'+': `Add tickers: `, '-': `Remove tickers: `, 'i': `Info: `}
 	if prompt, ok := prompts[command]; ok {
 		self.prompt = prompt
 		self.command = command
+		self.historyIndex = -1
 
 		self.screen.DrawLine(0, 3, `<white>` + self.prompt + `</>`)
 		termbox.SetCursor(len(self.prompt), 3)
@@ -46,12 +55,26 @@ func (self *LineEditor) Prompt(command rune) *LineEditor {
 func (self *LineEditor) Handle(ev termbox.Event) bool {
 	defer termbox.Flush()
 
+	if self.panel {
+		// Any key dismisses the security info popup rather than being
+		// interpreted as editing input.
+		self.panel = false
+		self.screen.ClearPanel()
+		return self.done()
+	}
+
+	if self.searching {
+		return self.handle_search(ev)
+	}
+
 	switch ev.Key {
 	case termbox.KeyEsc:
 		return self.done()
 
 	case termbox.KeyEnter:
-		return self.execute().done()
+		if self.execute() {
+			return self.done()
+		}
 
         case termbox.KeyBackspace, termbox.KeyBackspace2:
 		self.delete_previous_character()
@@ -68,6 +91,15 @@ func (self *LineEditor) Handle(ev termbox.Event) bool {
 	case termbox.KeyCtrlE:
 		self.jump_to_end()
 
+	case termbox.KeyArrowUp:
+		self.history_previous()
+
+	case termbox.KeyArrowDown:
+		self.history_next()
+
+	case termbox.KeyCtrlR:
+		self.start_search()
+
 	case termbox.KeySpace:
 		self.insert_character(' ')
 
@@ -80,8 +112,51 @@ func (self *LineEditor) Handle(ev termbox.Event) bool {
 	return false
 }
 
+//-----------------------------------------------------------------------------
+// handle_search processes a key event while in KeyCtrlR incremental
+// reverse-search mode, where typing narrows the match instead of editing
+// self.input directly.
+func (self *LineEditor) handle_search(ev termbox.Event) bool {
+	switch ev.Key {
+	case termbox.KeyEsc:
+		return self.end_search(false)
+
+	case termbox.KeyEnter:
+		return self.end_search(true)
+
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if len(self.searchQuery) > 0 {
+			self.searchQuery = self.searchQuery[:len(self.searchQuery)-1]
+		}
+		self.searchPos = 0
+		self.search_for(self.searchQuery)
+
+	case termbox.KeyCtrlR:
+		// Repeated Ctrl-R hops to the next older match for the same query.
+		if self.nth_history_match(self.searchQuery, self.searchPos+1) != `` {
+			self.searchPos++
+		}
+		self.search_for(self.searchQuery)
+
+	case termbox.KeySpace:
+		self.searchQuery += ` `
+		self.searchPos = 0
+		self.search_for(self.searchQuery)
+
+	default:
+		if ev.Ch != 0 {
+			self.searchQuery += string(ev.Ch)
+			self.searchPos = 0
+			self.search_for(self.searchQuery)
+		}
+	}
+
+	return false
+}
+
 //-----------------------------------------------------------------------------
 func (self *LineEditor) delete_previous_character() *LineEditor {
+	self.historyIndex = -1
 	if self.cursor > 0 {
 		if self.cursor < len(self.input) {
 			// Remove character in the middle of the input string.
@@ -99,6 +174,7 @@ func (self *LineEditor) delete_previous_character() *LineEditor {
 
 //-----------------------------------------------------------------------------
 func (self *LineEditor) insert_character(ch rune) *LineEditor {
+	self.historyIndex = -1
 	if self.cursor < len(self.input) {
 		// Insert the character in the middle of the input string.
 		self.input = self.input[0 : self.cursor] + string(ch) + self.input[self.cursor : len(self.input)]
@@ -149,15 +225,156 @@ func (self *LineEditor) jump_to_end() *LineEditor {
 }
 
 //-----------------------------------------------------------------------------
-func (self *LineEditor) execute() *LineEditor {
+func (self *LineEditor) history_previous() *LineEditor {
+	history := self.quotes.profile.History
+	if len(history) == 0 {
+		return self
+	}
+	if self.historyIndex < 0 {
+		self.savedInput = self.input
+		self.historyIndex = len(history)
+	}
+	if self.historyIndex > 0 {
+		self.historyIndex--
+	}
+
+	return self.set_input(history[self.historyIndex])
+}
+
+//-----------------------------------------------------------------------------
+func (self *LineEditor) history_next() *LineEditor {
+	if self.historyIndex < 0 {
+		return self
+	}
+
+	history := self.quotes.profile.History
+	self.historyIndex++
+	if self.historyIndex >= len(history) {
+		self.historyIndex = -1
+		return self.set_input(self.savedInput)
+	}
+
+	return self.set_input(history[self.historyIndex])
+}
+
+//-----------------------------------------------------------------------------
+func (self *LineEditor) set_input(value string) *LineEditor {
+	self.input = value
+	self.cursor = len(self.input)
+	self.screen.DrawLine(len(self.prompt), 3, self.input + ` `)
+	termbox.SetCursor(len(self.prompt) + self.cursor, 3)
+
+	return self
+}
+
+//-----------------------------------------------------------------------------
+func (self *LineEditor) start_search() *LineEditor {
+	self.searching = true
+	self.searchQuery = ``
+	self.searchPos = 0
+	self.historyIndex = -1 // A match accepted here shouldn't be clobbered by a later arrow-key history walk.
+	self.savedInput = self.input
+	self.draw_search()
+
+	return self
+}
+
+//-----------------------------------------------------------------------------
+// end_search leaves reverse-search mode, keeping the matched input if accept
+// is true (Enter) or restoring what was there before the search started
+// otherwise (Esc).
+func (self *LineEditor) end_search(accept bool) bool {
+	self.searching = false
+	if !accept {
+		self.input = self.savedInput
+	}
+	self.cursor = len(self.input)
+	rendered := self.prompt + self.input // What DrawLine actually puts on screen -- the <white>/</> tags are markup, not cells.
+	self.screen.DrawLine(0, 3, `<white>` + padLine(rendered, self.searchLineWidth) + `</>`)
+	self.searchLineWidth = 0
+	termbox.SetCursor(len(self.prompt) + self.cursor, 3)
+
+	return false
+}
+
+//-----------------------------------------------------------------------------
+func (self *LineEditor) search_for(query string) *LineEditor {
+	if query == `` {
+		self.input = ``
+	} else if match := self.nth_history_match(query, self.searchPos); match != `` {
+		self.input = match
+	}
+	self.draw_search()
+
+	return self
+}
+
+//-----------------------------------------------------------------------------
+// nth_history_match returns the (skip+1)-th most recent history entry
+// containing query, newest first, or `` if there aren't that many.
+func (self *LineEditor) nth_history_match(query string, skip int) string {
+	history := self.quotes.profile.History
+	found := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToUpper(history[i]), strings.ToUpper(query)) {
+			if found == skip {
+				return history[i]
+			}
+			found++
+		}
+	}
+
+	return ``
+}
+
+//-----------------------------------------------------------------------------
+func (self *LineEditor) draw_search() *LineEditor {
+	line := fmt.Sprintf(`(reverse-i-search)'%s': %s`, self.searchQuery, self.input)
+	self.screen.DrawLine(0, 3, padLine(line, self.searchLineWidth))
+	self.searchLineWidth = len(line)
+
+	return self
+}
+
+//-----------------------------------------------------------------------------
+// padLine pads s with trailing spaces up to minWidth so it overwrites every
+// cell a previous, longer line left behind -- DrawLine only draws the
+// characters it's given, it doesn't clear the rest of the row.
+func padLine(s string, minWidth int) string {
+	if pad := minWidth - len(s); pad > 0 {
+		s += strings.Repeat(` `, pad)
+	}
+
+	return s
+}
+
+//-----------------------------------------------------------------------------
+// recordHistory saves the just-submitted input to the profile's history,
+// shared by the '+' and '-' commands.
+func (self *LineEditor) recordHistory() {
+	self.quotes.profile.AddHistory(strings.TrimSpace(self.input))
+}
+
+//-----------------------------------------------------------------------------
+// execute runs the current command against self.input and reports whether
+// the prompt should close (true) or stay open (false: when '+' is given an
+// unrecognized ticker format, and for 'i', which keeps the prompt active
+// either to show a fetch error or to dismiss the info panel on the next key).
+func (self *LineEditor) execute() bool {
 	switch self.command {
 	case '+':
-		tickers := self.tokenize()
+		tickers, invalid := self.partitionTickers(self.tokenize())
+		if len(invalid) > 0 {
+			self.screen.DrawLine(0, 3, `<red>Unrecognized ticker format: ` + strings.Join(invalid, `, `) + `</>`)
+			termbox.Flush()
+			return false
+		}
 		if len(tickers) > 0 {
 			if added,_ := self.quotes.AddTickers(tickers); added > 0 {
 				self.screen.Draw(self.quotes)
 			}
 		}
+		self.recordHistory()
 	case '-':
 		tickers := self.tokenize()
 		if len(tickers) > 0 {
@@ -173,9 +390,31 @@ func (self *LineEditor) execute() *LineEditor {
 				}
 			}
 		}
+		self.recordHistory()
+	case 'i':
+		ticker := strings.ToUpper(strings.TrimSpace(self.input))
+		if ticker == `` {
+			break
+		}
+		// NewSecurityInfo().Fetch blocks on up to three sequential HTTP
+		// round-trips (cookies, crumb, quoteSummary) right here on the
+		// input-handling path, so the UI is unresponsive for the duration --
+		// same tradeoff NewMarket/NewQuotes already make at startup. If
+		// mop's regular quote fetching runs on a timer off the event loop
+		// elsewhere, this should move there too instead of running inline.
+		info := NewSecurityInfo().Fetch(ticker)
+		if ok, errors := info.Ok(); ok {
+			self.screen.DrawPanel(info)
+			self.panel = true
+			return false // Stay active so the next key dismisses the panel instead of editing.
+		} else {
+			self.screen.DrawLine(0, 3, `<red>` + errors + `</>`)
+			termbox.Flush()
+			return false
+		}
 	}
 
-	return self
+	return true
 }
 
 //-----------------------------------------------------------------------------
@@ -189,5 +428,37 @@ func (self *LineEditor) done() bool {
 //-----------------------------------------------------------------------------
 func (self *LineEditor) tokenize() []string {
 	input := strings.ToUpper(strings.TrimSpace(self.input))
-	return regexp.MustCompile(`[,\s]+`).Split(input, -1)
+	if input == `` {
+		return nil
+	}
+
+	var tokens []string
+	for _, token := range regexp.MustCompile(`[,\s]+`).Split(input, -1) {
+		if token != `` { // A leading/trailing separator (e.g. "AAPL,") splits off an empty token.
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
+
+// validTickerPattern matches every ticker format mop understands: plain
+// US/HK-suffixed symbols (AAPL, BTC-USD, ^DJI, EUR=X, 0700.HK) and
+// Shanghai/Shenzhen-prefixed symbols (sh000001, sz399001, sz399006).
+var validTickerPattern = regexp.MustCompile(`(?i)^(\^?[A-Z0-9]+([.\-=][A-Z0-9]+)*|(sh|sz)\d{6})$`)
+
+//-----------------------------------------------------------------------------
+// partitionTickers splits tokens into ones that look like a ticker mop (or
+// one of its data providers) can route, and ones that don't -- so execute()
+// can surface a visible error instead of silently dropping the latter.
+func (self *LineEditor) partitionTickers(tokens []string) (valid []string, invalid []string) {
+	for _, token := range tokens {
+		if validTickerPattern.MatchString(token) {
+			valid = append(valid, token)
+		} else {
+			invalid = append(invalid, token)
+		}
+	}
+
+	return valid, invalid
 }
\ No newline at end of file
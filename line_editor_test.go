@@ -0,0 +1,26 @@
+// Copyright (c) 2013 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mop
+
+import "testing"
+
+func TestPadLine(t *testing.T) {
+	tests := []struct {
+		s        string
+		minWidth int
+		want     string
+	}{
+		{`abc`, 5, `abc  `},
+		{`abc`, 3, `abc`},
+		{`abc`, 0, `abc`},
+		{``, 3, `   `},
+	}
+
+	for _, test := range tests {
+		if got := padLine(test.s, test.minWidth); got != test.want {
+			t.Errorf(`padLine(%q, %d) = %q, want %q`, test.s, test.minWidth, got, test.want)
+		}
+	}
+}
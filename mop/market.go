@@ -0,0 +1,111 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+// MarketProvider is implemented by each upstream data source capable of
+// supplying the index/indicator quotes shown on the market line. Swapping
+// providers -- e.g. when Yahoo's crumb/cookie flow breaks -- is a matter of
+// picking a different implementation rather than forking the codebase, which
+// is how this used to be handled historically (CNN, then Yahoo).
+type MarketProvider interface {
+	// Fetch populates market's index hashes (Dow, Nasdaq, etc.) from the
+	// provider's upstream source and returns market back to the caller.
+	// IsClosed is set by Market.Fetch(), not by the provider. On failure it
+	// should panic; Market.Fetch() recovers.
+	Fetch(market *Market) *Market
+}
+
+const defaultMarketProvider = `yahoo`
+
+// marketProviders maps a Profile.MarketProvider name to the constructor for
+// the matching MarketProvider implementation.
+var marketProviders = map[string]func() MarketProvider{
+	`yahoo`: func() MarketProvider { return NewYahooMarketProvider() },
+	`stooq`: func() MarketProvider { return NewStooqMarketProvider() },
+}
+
+// Market stores current market information displayed in the top three lines of
+// the screen. The data is fetched and parsed by whichever MarketProvider
+// backend is configured in the user's profile.
+type Market struct {
+	IsClosed  bool              // True when U.S. markets are closed.
+	Dow       map[string]string // Hash of Dow Jones indicators.
+	Nasdaq    map[string]string // Hash of NASDAQ indicators.
+	Sp500     map[string]string // Hash of S&P 500 indicators.
+	Btc       map[string]string
+	Tokyo     map[string]string
+	HongKong  map[string]string
+	London    map[string]string
+	Frankfurt map[string]string
+	Yield     map[string]string
+	Silver    map[string]string
+	Yen       map[string]string
+	Rub       map[string]string
+	Gbp       map[string]string
+	Euro      map[string]string
+	Gold      map[string]string
+	errors    string         // Error(s), if any.
+	provider  MarketProvider // Backend selected from the user's profile.
+}
+
+// NewMarket returns new initialized Market struct with the MarketProvider
+// backend selected by profile.MarketProvider (defaulting to Yahoo when the
+// profile doesn't name one or names one we don't recognize).
+func NewMarket(profile *Profile) *Market {
+	market := &Market{}
+	market.IsClosed = false
+	market.Dow = make(map[string]string)
+	market.Nasdaq = make(map[string]string)
+	market.Sp500 = make(map[string]string)
+	market.Btc = make(map[string]string)
+
+	market.Tokyo = make(map[string]string)
+	market.HongKong = make(map[string]string)
+	market.London = make(map[string]string)
+	market.Frankfurt = make(map[string]string)
+
+	market.Yield = make(map[string]string)
+	market.Silver = make(map[string]string)
+	market.Yen = make(map[string]string)
+	market.Euro = make(map[string]string)
+	market.Gold = make(map[string]string)
+
+	name := defaultMarketProvider
+	if profile != nil && profile.MarketProvider != `` {
+		name = profile.MarketProvider
+	}
+	ctor, ok := marketProviders[name]
+	if !ok {
+		ctor = marketProviders[defaultMarketProvider]
+	}
+	market.provider = ctor()
+
+	market.errors = ``
+
+	return market
+}
+
+// Fetch downloads and parses the current market data using the configured
+// MarketProvider. If the provider fails Fetch recovers and leaves
+// 'market.errors' empty so nothing is drawn on the screen.
+func (market *Market) Fetch() (self *Market) {
+	self = market // <-- This ensures we return correct market after recover() from panic().
+	defer func() {
+		if err := recover(); err != nil {
+			market.errors = "" // Don't pollute the screen
+		}
+	}()
+
+	state, _ := market.SessionState(`NYSE`)
+	market.IsClosed = state == Closed
+
+	return market.provider.Fetch(market)
+}
+
+// Ok returns two values: 1) boolean indicating whether the error has occurred,
+// and 2) the error text itself.
+func (market *Market) Ok() (bool, string) {
+	return market.errors == ``, market.errors
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import "time"
+
+// marketHolidays is a small embedded holiday calendar of full-day market
+// closures, keyed by exchange, each holding a list of dates (YYYY-MM-DD, in
+// the exchange's own timezone). It only needs to cover the current year plus a
+// little lookahead and should be refreshed yearly.
+var marketHolidays = map[string][]string{
+	`NYSE`: {
+		`2026-01-01`, `2026-01-19`, `2026-02-16`, `2026-04-03`, `2026-05-25`,
+		`2026-06-19`, `2026-07-03`, `2026-09-07`, `2026-11-26`, `2026-12-25`,
+	},
+	`LSE`: {
+		`2026-01-01`, `2026-04-03`, `2026-04-06`, `2026-05-04`, `2026-05-25`,
+		`2026-08-31`, `2026-12-25`, `2026-12-28`,
+	},
+	`TSE`: {
+		`2026-01-01`, `2026-01-02`, `2026-01-03`, `2026-01-12`, `2026-02-11`,
+		`2026-02-23`, `2026-03-20`, `2026-04-29`, `2026-05-04`, `2026-05-05`,
+		`2026-05-06`, `2026-07-20`, `2026-08-11`, `2026-09-21`, `2026-09-22`,
+		`2026-10-12`, `2026-11-03`, `2026-11-23`,
+	},
+	`HKEX`: {
+		`2026-01-01`, `2026-02-17`, `2026-02-18`, `2026-02-19`, `2026-04-03`,
+		`2026-04-06`, `2026-05-01`, `2026-05-25`, `2026-06-19`, `2026-09-26`,
+		`2026-10-01`, `2026-12-25`,
+	},
+	`XETRA`: {
+		`2026-01-01`, `2026-04-03`, `2026-04-06`, `2026-05-01`, `2026-12-24`,
+		`2026-12-25`, `2026-12-31`,
+	},
+}
+
+// isHoliday reports whether t's calendar date is a full market closure for
+// index's exchange.
+func isHoliday(index string, t time.Time) bool {
+	today := t.Format(`2006-01-02`)
+	for _, date := range marketHolidays[index] {
+		if date == today {
+			return true
+		}
+	}
+	return false
+}
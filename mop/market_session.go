@@ -0,0 +1,111 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import "time"
+
+// SessionState describes where a tracked exchange currently sits in its
+// trading day.
+type SessionState int
+
+const (
+	Closed SessionState = iota
+	PreMarket
+	Open
+	PostMarket
+)
+
+// String renders state the way the formatter would want to show it.
+func (state SessionState) String() string {
+	switch state {
+	case Open:
+		return `Open`
+	case PreMarket:
+		return `PreMarket`
+	case PostMarket:
+		return `PostMarket`
+	default:
+		return `Closed`
+	}
+}
+
+// exchangeSession describes one exchange's regular trading hours, in its own
+// local timezone, as offsets from local midnight.
+type exchangeSession struct {
+	timezone  string
+	preOpen   time.Duration
+	open      time.Duration
+	close     time.Duration
+	postClose time.Duration
+}
+
+// exchangeSessions covers the indices Market tracks. Hours are each
+// exchange's normal regular/pre/post session, not adjusted for one-off
+// schedule changes beyond the holidays in marketHolidays.
+var exchangeSessions = map[string]exchangeSession{
+	`NYSE`:  {`America/New_York`, 4 * time.Hour, 9*time.Hour + 30*time.Minute, 16 * time.Hour, 20 * time.Hour},
+	`LSE`:   {`Europe/London`, 7*time.Hour + 30*time.Minute, 8 * time.Hour, 16*time.Hour + 30*time.Minute, 17 * time.Hour},
+	`TSE`:   {`Asia/Tokyo`, 8 * time.Hour, 9 * time.Hour, 15 * time.Hour, 15*time.Hour + 30*time.Minute},
+	`HKEX`:  {`Asia/Hong_Kong`, 9 * time.Hour, 9*time.Hour + 30*time.Minute, 16 * time.Hour, 16*time.Hour + 30*time.Minute},
+	`XETRA`: {`Europe/Berlin`, 7*time.Hour + 30*time.Minute, 9 * time.Hour, 17*time.Hour + 30*time.Minute, 18 * time.Hour},
+}
+
+// SessionState returns where index's exchange currently sits in its trading
+// day and when that will next change. Weekends and the dates in
+// marketHolidays are treated as fully closed, with no pre/post session
+// either. Unrecognized indices fall back to NYSE hours.
+func (market *Market) SessionState(index string) (SessionState, time.Time) {
+	session, ok := exchangeSessions[index]
+	if !ok {
+		index = `NYSE`
+		session = exchangeSessions[index]
+	}
+
+	location, err := time.LoadLocation(session.timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	now := time.Now().In(location)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	if isWeekend(now) || isHoliday(index, now) {
+		return Closed, nextTradingOpen(midnight, index, session)
+	}
+
+	preOpen := midnight.Add(session.preOpen)
+	open := midnight.Add(session.open)
+	close := midnight.Add(session.close)
+	postClose := midnight.Add(session.postClose)
+
+	switch {
+	case now.Before(preOpen):
+		return Closed, preOpen
+	case now.Before(open):
+		return PreMarket, open
+	case now.Before(close):
+		return Open, close
+	case now.Before(postClose):
+		return PostMarket, postClose
+	default:
+		return Closed, nextTradingOpen(midnight, index, session)
+	}
+}
+
+// isWeekend reports whether t falls on a Saturday or Sunday in its own
+// timezone.
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// nextTradingOpen walks forward from midnight a day at a time, skipping
+// weekends and holidays, and returns the pre-market open of the next trading
+// day.
+func nextTradingOpen(midnight time.Time, index string, session exchangeSession) time.Time {
+	next := midnight.AddDate(0, 0, 1)
+	for isWeekend(next) || isHoliday(index, next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Add(session.preOpen)
+}
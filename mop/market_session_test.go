@@ -0,0 +1,71 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsWeekend(t *testing.T) {
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{`2026-07-25`, true},  // Saturday
+		{`2026-07-26`, true},  // Sunday
+		{`2026-07-27`, false}, // Monday
+	}
+
+	for _, test := range tests {
+		date, err := time.Parse(`2006-01-02`, test.date)
+		if err != nil {
+			t.Fatalf(`time.Parse(%q) failed: %v`, test.date, err)
+		}
+		if got := isWeekend(date); got != test.want {
+			t.Errorf(`isWeekend(%s) = %v, want %v`, test.date, got, test.want)
+		}
+	}
+}
+
+func TestIsHoliday(t *testing.T) {
+	tests := []struct {
+		index string
+		date  string
+		want  bool
+	}{
+		{`NYSE`, `2026-01-01`, true},
+		{`NYSE`, `2026-01-02`, false},
+		{`TSE`, `2026-01-01`, true},
+		{`NYSE`, `2026-01-02`, false}, // A TSE holiday shouldn't leak into NYSE's calendar.
+	}
+
+	for _, test := range tests {
+		date, err := time.Parse(`2006-01-02`, test.date)
+		if err != nil {
+			t.Fatalf(`time.Parse(%q) failed: %v`, test.date, err)
+		}
+		if got := isHoliday(test.index, date); got != test.want {
+			t.Errorf(`isHoliday(%q, %s) = %v, want %v`, test.index, test.date, got, test.want)
+		}
+	}
+}
+
+func TestNextTradingOpenSkipsWeekendAndHoliday(t *testing.T) {
+	session := exchangeSessions[`NYSE`]
+	location, err := time.LoadLocation(session.timezone)
+	if err != nil {
+		t.Fatalf(`time.LoadLocation(%q) failed: %v`, session.timezone, err)
+	}
+
+	// Friday 2026-01-16 is followed by a weekend and then the 2026-01-19
+	// holiday, so the next trading open should land on 2026-01-20.
+	friday := time.Date(2026, time.January, 16, 0, 0, 0, 0, location)
+	want := time.Date(2026, time.January, 20, 0, 0, 0, 0, location).Add(session.preOpen)
+
+	if got := nextTradingOpen(friday, `NYSE`, session); !got.Equal(want) {
+		t.Errorf(`nextTradingOpen(%s, "NYSE", ...) = %s, want %s`, friday, got, want)
+	}
+}
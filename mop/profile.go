@@ -0,0 +1,84 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const defaultProfileFilename = `.moprc`
+const maxHistorySize = 50
+
+// Profile stores the user's preferences -- the watchlist, which data source
+// backends to use, and recent +/- command history -- persisted as JSON in
+// ~/.moprc across mop sessions.
+type Profile struct {
+	Tickers        []string // List of ticker symbols to display.
+	MarketProvider string   // Name of the MarketProvider backend, e.g. `yahoo` or `stooq`.
+	QuotesProvider string   // Name of the QuotesProvider backend, e.g. `yahoo` or `stooq`.
+	History        []string // Most recent +/- prompt inputs, oldest first.
+	filename       string   // Where the profile is persisted.
+}
+
+// NewProfile returns a Profile loaded from ~/.moprc, or a blank one if the
+// file doesn't exist yet.
+func NewProfile() *Profile {
+	profile := &Profile{}
+	profile.filename = profilePath()
+	profile.Reload()
+
+	return profile
+}
+
+// -----------------------------------------------------------------------------
+func profilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return defaultProfileFilename
+	}
+	return filepath.Join(home, defaultProfileFilename)
+}
+
+// Reload re-reads the profile from disk, leaving it unchanged if the file
+// doesn't exist yet or can't be parsed.
+func (profile *Profile) Reload() *Profile {
+	if contents, err := ioutil.ReadFile(profile.filename); err == nil {
+		json.Unmarshal(contents, profile)
+	}
+
+	return profile
+}
+
+// Save writes the profile back to its file.
+func (profile *Profile) Save() *Profile {
+	if contents, err := json.MarshalIndent(profile, ``, `  `); err == nil {
+		ioutil.WriteFile(profile.filename, contents, 0644)
+	}
+
+	return profile
+}
+
+// AddHistory appends input to the command history -- skipping blanks and
+// immediate repeats -- trims it down to maxHistorySize, and persists the
+// profile.
+func (profile *Profile) AddHistory(input string) *Profile {
+	if input == `` {
+		return profile
+	}
+	if n := len(profile.History); n > 0 && profile.History[n-1] == input {
+		return profile
+	}
+
+	profile.History = append(profile.History, input)
+	if len(profile.History) > maxHistorySize {
+		profile.History = profile.History[len(profile.History)-maxHistorySize:]
+	}
+	profile.Save()
+
+	return profile
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+// QuotesProvider is implemented by each upstream data source capable of
+// fetching live quotes for an arbitrary list of tickers. Like MarketProvider,
+// this turns a data-source swap into a configuration choice instead of a
+// fork of the whole tree.
+type QuotesProvider interface {
+	// Fetch retrieves current quotes for the given tickers and returns them
+	// keyed by ticker symbol.
+	Fetch(tickers []string) (map[string]map[string]string, error)
+}
+
+const defaultQuotesProvider = `yahoo`
+
+// quotesProviders maps a Profile.QuotesProvider name to the constructor for
+// the matching QuotesProvider implementation.
+var quotesProviders = map[string]func() QuotesProvider{
+	`yahoo`: func() QuotesProvider { return NewYahooQuotesProvider() },
+	`stooq`: func() QuotesProvider { return NewStooqQuotesProvider() },
+}
+
+// Quotes stores the live data for the tickers on the user's watchlist. The
+// data is fetched and parsed by whichever QuotesProvider backend is
+// configured in the user's profile.
+type Quotes struct {
+	Stocks   []map[string]string // One hash of indicators per ticker that returned data, in profile.Tickers order.
+	errors   string              // Error(s), if any.
+	profile  *Profile            // User's watchlist and backend preferences.
+	provider QuotesProvider      // Backend selected from the user's profile.
+}
+
+// NewQuotes returns a new initialized Quotes struct with the QuotesProvider
+// backend selected by profile.QuotesProvider (defaulting to Yahoo when the
+// profile doesn't name one or names one we don't recognize), wrapped in a
+// tickerRouter so CN-prefixed tickers are always routed to Sina regardless
+// of which backend is selected.
+func NewQuotes(profile *Profile) *Quotes {
+	quotes := &Quotes{}
+	quotes.profile = profile
+	quotes.errors = ``
+
+	name := defaultQuotesProvider
+	if profile != nil && profile.QuotesProvider != `` {
+		name = profile.QuotesProvider
+	}
+	ctor, ok := quotesProviders[name]
+	if !ok {
+		ctor = quotesProviders[defaultQuotesProvider]
+	}
+	quotes.provider = newTickerRouter(ctor())
+
+	return quotes
+}
+
+// Fetch downloads and parses the current quotes for the watchlist using the
+// configured QuotesProvider. If the provider fails Fetch recovers and leaves
+// 'quotes.errors' empty so nothing is drawn on the screen.
+func (quotes *Quotes) Fetch() (self *Quotes) {
+	self = quotes // <-- This ensures we return correct quotes after recover() from panic().
+	defer func() {
+		if err := recover(); err != nil {
+			quotes.errors = "" // Don't pollute the screen
+		}
+	}()
+
+	data, err := quotes.provider.Fetch(quotes.profile.Tickers)
+	if err != nil {
+		panic(err)
+	}
+
+	stocks := make([]map[string]string, 0, len(quotes.profile.Tickers))
+	for _, ticker := range quotes.profile.Tickers {
+		if stock, ok := data[ticker]; ok {
+			stocks = append(stocks, stock)
+		}
+	}
+	quotes.Stocks = stocks
+
+	return quotes
+}
+
+// Ok returns two values: 1) boolean indicating whether the error has occurred,
+// and 2) the error text itself.
+func (quotes *Quotes) Ok() (bool, string) {
+	return quotes.errors == ``, quotes.errors
+}
+
+// AddTickers appends the given tickers to the watchlist, skipping blanks and
+// tickers that are already present, and persists the profile regardless of
+// whether the command history (a separate concern) ends up recording this
+// input.
+func (quotes *Quotes) AddTickers(tickers []string) (added int, err error) {
+	existing := make(map[string]bool, len(quotes.profile.Tickers))
+	for _, ticker := range quotes.profile.Tickers {
+		existing[ticker] = true
+	}
+
+	for _, ticker := range tickers {
+		if ticker == `` || existing[ticker] {
+			continue
+		}
+		quotes.profile.Tickers = append(quotes.profile.Tickers, ticker)
+		existing[ticker] = true
+		added++
+	}
+
+	if added > 0 {
+		quotes.profile.Save()
+	}
+
+	return added, nil
+}
+
+// RemoveTickers removes the given tickers from the watchlist and persists
+// the profile regardless of whether the command history (a separate
+// concern) ends up recording this input.
+func (quotes *Quotes) RemoveTickers(tickers []string) (removed int, err error) {
+	remove := make(map[string]bool, len(tickers))
+	for _, ticker := range tickers {
+		remove[ticker] = true
+	}
+
+	kept := quotes.profile.Tickers[:0]
+	for _, ticker := range quotes.profile.Tickers {
+		if remove[ticker] {
+			removed++
+			continue
+		}
+		kept = append(kept, ticker)
+	}
+	quotes.profile.Tickers = kept
+
+	if removed > 0 {
+		quotes.profile.Save()
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"fmt"
+	"strings"
+)
+
+const panelTop = 5
+const panelWidth = 42
+
+// DrawPanel overlays a security info box a few rows below the prompt line,
+// giving enough at-a-glance research detail to skip a trip to the browser.
+// It stays up until LineEditor dismisses it (via ClearPanel) on the user's
+// next keypress.
+func (screen *Screen) DrawPanel(info *SecurityInfo) *Screen {
+	lines := []string{
+		fmt.Sprintf(`<white>%s (%s)</>`, info.Name, info.Ticker),
+		fmt.Sprintf(`Exchange: %s   Sector: %s`, info.Exchange, info.Sector),
+		fmt.Sprintf(`Market Cap: %s`, info.MarketCap),
+		fmt.Sprintf(`52-Week Range: %s - %s`, info.WeekLow, info.WeekHigh),
+		fmt.Sprintf(`P/E: %s   Dividend Yield: %s`, info.PE, info.DividendYield),
+	}
+
+	screen.DrawLine(0, panelTop, strings.Repeat(`-`, panelWidth))
+	for i, line := range lines {
+		screen.DrawLine(0, panelTop+1+i, line)
+	}
+	screen.DrawLine(0, panelTop+1+len(lines), strings.Repeat(`-`, panelWidth))
+
+	return screen
+}
+
+// ClearPanel erases the overlay drawn by DrawPanel.
+func (screen *Screen) ClearPanel() *Screen {
+	first, last := panelRowRange()
+	for row := first; row <= last; row++ {
+		screen.ClearLine(0, row)
+	}
+
+	return screen
+}
+
+// panelRowRange returns the first and last screen rows DrawPanel writes to --
+// a top separator, the 5 info lines, and a bottom separator -- so ClearPanel
+// erases exactly what was drawn and nothing past it.
+func panelRowRange() (first, last int) {
+	return panelTop, panelTop + 6
+}
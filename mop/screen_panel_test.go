@@ -0,0 +1,17 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import "testing"
+
+func TestPanelRowRange(t *testing.T) {
+	first, last := panelRowRange()
+	if first != panelTop {
+		t.Errorf(`panelRowRange() first = %d, want %d`, first, panelTop)
+	}
+	if last != panelTop+6 {
+		t.Errorf(`panelRowRange() last = %d, want %d`, last, panelTop+6)
+	}
+}
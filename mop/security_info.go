@@ -0,0 +1,137 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const securityInfoURL = `https://query1.finance.yahoo.com/v10/finance/quoteSummary/%s?crumb=%s&modules=price,summaryDetail,summaryProfile`
+
+// SecurityInfo is an on-demand research snapshot for a single ticker --
+// enough to answer "what is this" without adding it to the watchlist or
+// leaving mop for a browser. It's fetched and displayed by the 'i' prompt
+// command.
+type SecurityInfo struct {
+	Ticker        string
+	Name          string
+	Exchange      string
+	Sector        string
+	MarketCap     string
+	WeekLow       string
+	WeekHigh      string
+	PE            string
+	DividendYield string
+	errors        string // Error(s), if any.
+	cookies       string // cookies for auth
+	crumb         string // crumb for the cookies, to be applied as a query param
+}
+
+// NewSecurityInfo returns a SecurityInfo fetcher with the same crumb/cookie
+// authentication NewYahooMarketProvider negotiates, since quoteSummary sits
+// behind the same auth as the quote endpoint.
+func NewSecurityInfo() *SecurityInfo {
+	info := &SecurityInfo{}
+	info.cookies = fetchCookies()
+	info.crumb = fetchCrumb(info.cookies)
+
+	return info
+}
+
+// Fetch downloads and parses Yahoo's quoteSummary for ticker. If download or
+// parsing fails Fetch populates 'info.errors'.
+func (info *SecurityInfo) Fetch(ticker string) (self *SecurityInfo) {
+	self = info // <-- This ensures we return correct info after recover() from panic().
+	defer func() {
+		if err := recover(); err != nil {
+			info.errors = fmt.Sprintf(`%v`, err)
+		}
+	}()
+
+	info.Ticker = ticker
+
+	client := http.Client{Timeout: 10 * time.Second}
+	request, err := http.NewRequest("GET", fmt.Sprintf(securityInfoURL, ticker, info.crumb), nil)
+	if err != nil {
+		panic(err)
+	}
+	request.Header = http.Header{
+		"Accept":     {"*/*"},
+		"Cookie":     {info.cookies},
+		"User-Agent": {userAgent},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		panic(err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		panic(err)
+	}
+
+	return info.extract(body)
+}
+
+// Ok returns two values: 1) boolean indicating whether the error has
+// occurred, and 2) the error text itself.
+func (info *SecurityInfo) Ok() (bool, string) {
+	return info.errors == ``, info.errors
+}
+
+// -----------------------------------------------------------------------------
+func (info *SecurityInfo) extract(body []byte) *SecurityInfo {
+	d := map[string]map[string][]map[string]interface{}{}
+	if err := json.Unmarshal(body, &d); err != nil {
+		panic(err)
+	}
+
+	results := d[`quoteSummary`][`result`]
+	if len(results) == 0 {
+		panic(`no data returned for ` + info.Ticker)
+	}
+	result := results[0]
+
+	if price, ok := result[`price`].(map[string]interface{}); ok {
+		info.Name = stringField(price, `longName`)
+		info.Exchange = stringField(price, `exchangeName`)
+		info.MarketCap = formattedField(price, `marketCap`)
+	}
+	if detail, ok := result[`summaryDetail`].(map[string]interface{}); ok {
+		info.WeekLow = formattedField(detail, `fiftyTwoWeekLow`)
+		info.WeekHigh = formattedField(detail, `fiftyTwoWeekHigh`)
+		info.PE = formattedField(detail, `trailingPE`)
+		info.DividendYield = formattedField(detail, `dividendYield`)
+	}
+	if profile, ok := result[`summaryProfile`].(map[string]interface{}); ok {
+		info.Sector = stringField(profile, `sector`)
+	}
+
+	return info
+}
+
+// -----------------------------------------------------------------------------
+func stringField(m map[string]interface{}, key string) string {
+	if value, ok := m[key].(string); ok {
+		return value
+	}
+	return ``
+}
+
+// -----------------------------------------------------------------------------
+func formattedField(m map[string]interface{}, key string) string {
+	if nested, ok := m[key].(map[string]interface{}); ok {
+		if value, ok := nested[`fmt`].(string); ok {
+			return value
+		}
+	}
+	return ``
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sinaURL fetches Sina Finance's lightweight quote feed, one semicolon
+// separated `var hq_str_<symbol>="...";` line per requested symbol.
+const sinaURL = `https://hq.sinajs.cn/list=%s`
+
+// SinaQuotesProvider implements QuotesProvider against Sina Finance's quote
+// feed, which covers the Shanghai/Shenzhen (sh000001, sz399001, ...) tickers
+// Yahoo doesn't carry.
+type SinaQuotesProvider struct{}
+
+// NewSinaQuotesProvider returns a SinaQuotesProvider. Sina's feed needs no
+// authentication so there's nothing to negotiate up front.
+func NewSinaQuotesProvider() *SinaQuotesProvider {
+	return &SinaQuotesProvider{}
+}
+
+// Fetch downloads and parses quotes for the given CN tickers from Sina.
+func (provider *SinaQuotesProvider) Fetch(tickers []string) (map[string]map[string]string, error) {
+	if len(tickers) == 0 {
+		return map[string]map[string]string{}, nil
+	}
+
+	symbols := make([]string, len(tickers))
+	for i, ticker := range tickers {
+		symbols[i] = strings.ToLower(ticker)
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	request, err := http.NewRequest("GET", fmt.Sprintf(sinaURL, strings.Join(symbols, `,`)), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Referer", "https://finance.sina.com.cn")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]map[string]string, len(tickers))
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for i := 0; scanner.Scan() && i < len(tickers); i++ {
+		start := strings.IndexByte(scanner.Text(), '"')
+		end := strings.LastIndexByte(scanner.Text(), '"')
+		if start < 0 || end <= start {
+			continue
+		}
+
+		// Index quotes come back as name,current,change,percent,open,... --
+		// close enough to our `latest`/`change`/`percent` shape to reuse.
+		fields := strings.Split(scanner.Text()[start+1:end], `,`)
+		if len(fields) < 4 {
+			continue
+		}
+		current, err1 := strconv.ParseFloat(fields[1], 64)
+		change, err2 := strconv.ParseFloat(fields[2], 64)
+		percent, err3 := strconv.ParseFloat(fields[3], 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+
+		out[tickers[i]] = map[string]string{
+			`latest`:  float2Str(current),
+			`change`:  float2Str(change),
+			`percent`: float2Str(percent) + `%`,
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// stooqURL fetches a snapshot CSV (symbol,date,time,open,high,low,close,volume)
+// for the given comma-separated list of Stooq symbols.
+const stooqURL = `https://stooq.com/q/l/?s=%s&f=sd2t2ohlcv&h&e=csv`
+
+// stooqSymbols maps Stooq's symbol naming to the same index slots the Yahoo
+// provider fills in, so the rest of mop can't tell the two backends apart.
+var stooqSymbols = []string{`^dji`, `^ixic`, `^spx`, `btc.v`, `^nkx`, `^hsi`, `^ftm`, `^dax`}
+
+// StooqMarketProvider implements MarketProvider against Stooq's free CSV
+// quote endpoint. It's a drop-in alternative for when Yahoo's crumb/cookie
+// flow breaks, at the cost of not covering every index Yahoo does.
+type StooqMarketProvider struct {
+	url string
+}
+
+// NewStooqMarketProvider returns a StooqMarketProvider with its request URL
+// already built. Unlike Yahoo, Stooq's CSV endpoint needs no authentication.
+func NewStooqMarketProvider() *StooqMarketProvider {
+	provider := &StooqMarketProvider{}
+	provider.url = fmt.Sprintf(stooqURL, strings.Join(stooqSymbols, `,`))
+
+	return provider
+}
+
+// Fetch downloads the CSV snapshot from Stooq and stores the resulting data
+// in market's hashes. If download or parsing fails Fetch panics so the
+// caller (Market.Fetch) can recover.
+func (provider *StooqMarketProvider) Fetch(market *Market) *Market {
+	client := http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	response, err := client.Get(provider.url)
+	if err != nil {
+		panic(err)
+	}
+	defer response.Body.Close()
+
+	rows, err := csv.NewReader(response.Body).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+
+	return provider.assign(market, rows)
+}
+
+// -----------------------------------------------------------------------------
+func (provider *StooqMarketProvider) assign(market *Market, rows [][]string) *Market {
+	targets := []*map[string]string{
+		&market.Dow, &market.Nasdaq, &market.Sp500, &market.Btc,
+		&market.Tokyo, &market.HongKong, &market.London, &market.Frankfurt,
+	}
+
+	for i, row := range rows {
+		if i == 0 || i-1 >= len(targets) || len(row) < 7 {
+			continue // Skip the CSV header and anything we don't have a slot for.
+		}
+		close, open := row[6], row[3]
+		*targets[i-1] = map[string]string{
+			`latest`: close,
+			`change`: stooqChange(open, close),
+		}
+	}
+
+	return market
+}
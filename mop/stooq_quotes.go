@@ -0,0 +1,79 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stooqQuotesURL fetches a snapshot CSV (symbol,date,time,open,high,low,close,volume)
+// for the given comma-separated list of Stooq symbols.
+const stooqQuotesURL = `https://stooq.com/q/l/?s=%s&f=sd2t2ohlcv&h&e=csv`
+
+// StooqQuotesProvider implements QuotesProvider against Stooq's free CSV
+// quote endpoint -- a drop-in alternative for when Yahoo's crumb/cookie flow
+// breaks.
+type StooqQuotesProvider struct{}
+
+// NewStooqQuotesProvider returns a StooqQuotesProvider. Unlike Yahoo, Stooq's
+// CSV endpoint needs no authentication so there's nothing to negotiate.
+func NewStooqQuotesProvider() *StooqQuotesProvider {
+	return &StooqQuotesProvider{}
+}
+
+// Fetch downloads and parses the CSV snapshot for the given tickers from
+// Stooq.
+func (provider *StooqQuotesProvider) Fetch(tickers []string) (map[string]map[string]string, error) {
+	if len(tickers) == 0 {
+		return map[string]map[string]string{}, nil
+	}
+
+	symbols := make([]string, len(tickers))
+	for i, ticker := range tickers {
+		symbols[i] = strings.ToLower(ticker)
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get(fmt.Sprintf(stooqQuotesURL, strings.Join(symbols, `,`)))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	rows, err := csv.NewReader(response.Body).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]map[string]string, len(tickers))
+	for i, row := range rows {
+		if i == 0 || i-1 >= len(tickers) || len(row) < 7 {
+			continue // Skip the CSV header and anything we don't have a ticker for.
+		}
+		open, close := row[3], row[6]
+		out[tickers[i-1]] = map[string]string{
+			`latest`: close,
+			`change`: stooqChange(open, close),
+		}
+	}
+
+	return out, nil
+}
+
+// stooqChange computes a simple open-to-close delta since Stooq's CSV,
+// unlike Yahoo's quoteResponse, doesn't return a precomputed change value.
+func stooqChange(open, close string) string {
+	o, err1 := strconv.ParseFloat(open, 64)
+	c, err2 := strconv.ParseFloat(close, 64)
+	if err1 != nil || err2 != nil {
+		return ``
+	}
+	return float2Str(c - o)
+}
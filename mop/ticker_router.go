@@ -0,0 +1,86 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"regexp"
+	"sync"
+)
+
+// cnTickerPattern matches Shanghai/Shenzhen exchange tickers such as
+// sh000001, sz399001, or sz399006.
+var cnTickerPattern = regexp.MustCompile(`(?i)^(sh|sz)\d{6}$`)
+
+// isCNTicker reports whether ticker should be routed to the CN quotes
+// backend instead of the default (Yahoo/Stooq) one.
+func isCNTicker(ticker string) bool {
+	return cnTickerPattern.MatchString(ticker)
+}
+
+// tickerRouter inspects each ticker's prefix/suffix and dispatches it to the
+// upstream best suited to fetch it -- the wrapped "default" backend for
+// US/HK-suffixed tickers (e.g. AAPL, 0700.HK), and a Sina-style CN backend
+// for Shanghai/Shenzhen-prefixed tickers -- fetching both concurrently and
+// merging the results into one unified quotes map. It implements
+// QuotesProvider so it can be dropped in wherever a single backend used to
+// be.
+type tickerRouter struct {
+	def QuotesProvider
+	cn  QuotesProvider
+}
+
+// newTickerRouter wraps def -- the QuotesProvider selected by the user's
+// profile -- with CN routing.
+func newTickerRouter(def QuotesProvider) *tickerRouter {
+	return &tickerRouter{
+		def: def,
+		cn:  NewSinaQuotesProvider(),
+	}
+}
+
+// Fetch splits tickers by market, fetches each group concurrently, and
+// merges the results back into a single map keyed by ticker.
+func (router *tickerRouter) Fetch(tickers []string) (map[string]map[string]string, error) {
+	var defTickers, cnTickers []string
+	for _, ticker := range tickers {
+		if isCNTicker(ticker) {
+			cnTickers = append(cnTickers, ticker)
+		} else {
+			defTickers = append(defTickers, ticker)
+		}
+	}
+
+	var defData, cnData map[string]map[string]string
+	var defErr, cnErr error
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defData, defErr = router.def.Fetch(defTickers)
+	}()
+	go func() {
+		defer wg.Done()
+		cnData, cnErr = router.cn.Fetch(cnTickers)
+	}()
+	wg.Wait()
+
+	if defErr != nil {
+		return nil, defErr
+	}
+	if cnErr != nil {
+		return nil, cnErr
+	}
+
+	merged := make(map[string]map[string]string, len(defData)+len(cnData))
+	for ticker, data := range defData {
+		merged[ticker] = data
+	}
+	for ticker, data := range cnData {
+		merged[ticker] = data
+	}
+
+	return merged, nil
+}
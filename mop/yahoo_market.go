@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"time"
 	"net/http"
 )
@@ -15,74 +16,42 @@ import (
 const marketURL = `https://query1.finance.yahoo.com/v7/finance/quote?crumb=%s&symbols=%s`
 const marketURLQueryParts = `&range=1d&interval=5m&indicators=close&includeTimestamps=false&includePrePost=false&corsDomain=finance.yahoo.com&.tsrc=finance`
 
-// Market stores current market information displayed in the top three lines of
-// the screen. The market data is fetched and parsed from the HTML page above.
-type Market struct {
-	IsClosed  bool              // True when U.S. markets are closed.
-	Dow       map[string]string // Hash of Dow Jones indicators.
-	Nasdaq    map[string]string // Hash of NASDAQ indicators.
-	Sp500     map[string]string // Hash of S&P 500 indicators.
-	Btc       map[string]string
-	Tokyo     map[string]string
-	HongKong  map[string]string
-	London    map[string]string
-	Frankfurt map[string]string
-	Yield     map[string]string
-	Silver    map[string]string
-	Yen       map[string]string
-	Rub       map[string]string
-	Gbp       map[string]string
-	Euro      map[string]string
-	Gold      map[string]string
-	errors    string // Error(s), if any.
-	url       string // URL with symbols to fetch data
-	cookies   string // cookies for auth
-	crumb     string // crumb for the cookies, to be applied as a query param
+// YahooMarketProvider implements MarketProvider against Yahoo Finance's
+// quote endpoint. This is the default and original market data backend.
+type YahooMarketProvider struct {
+	url     string // URL with symbols to fetch data
+	cookies string // cookies for auth
+	crumb   string // crumb for the cookies, to be applied as a query param
 }
 
-// Returns new initialized Market struct.
-func NewMarket() *Market {
-	market := &Market{}
-	market.IsClosed = false
-	market.Dow = make(map[string]string)
-	market.Nasdaq = make(map[string]string)
-	market.Sp500 = make(map[string]string)
-	market.Btc = make(map[string]string)
+// NewYahooMarketProvider returns a YahooMarketProvider with crumb/cookie
+// authentication already negotiated.
+func NewYahooMarketProvider() *YahooMarketProvider {
+	provider := &YahooMarketProvider{}
+	provider.cookies = fetchCookies()
+	provider.crumb = fetchCrumb(provider.cookies)
+	provider.url = fmt.Sprintf(marketURL, provider.crumb, `^DJI,^IXIC,^GSPC,BTC-USD,^N225,^HSI,^FTSE,^GDAXI,JPY=X,RUB=X,GBP=X,EUR=X,^TNX,SI=F,GC=F`) + marketURLQueryParts
 
-	market.Tokyo = make(map[string]string)
-	market.HongKong = make(map[string]string)
-	market.London = make(map[string]string)
-	market.Frankfurt = make(map[string]string)
-
-	market.Yield = make(map[string]string)
-	market.Silver = make(map[string]string)
-	market.Yen = make(map[string]string)
-	market.Euro = make(map[string]string)
-	market.Gold = make(map[string]string)
-
-	market.cookies = fetchCookies()
-	market.crumb = fetchCrumb(market.cookies)
-	market.url = fmt.Sprintf(marketURL, market.crumb, `^DJI,^IXIC,^GSPC,BTC-USD,^N225,^HSI,^FTSE,^GDAXI,JPY=X,RUB=X,GBP=X,EUR=X,^TNX,SI=F,GC=F`) + marketURLQueryParts
-
-	market.errors = ``
-
-	return market
+	return provider
 }
 
-// Fetch downloads HTML page from the 'marketURL', parses it, and stores resulting data
-// in internal hashes. If download or data parsing fails Fetch populates 'market.errors'.
-func (market *Market) Fetch() (self *Market) {
-	self = market // <-- This ensures we return correct market after recover() from panic().
-	defer func() {
-		if err := recover(); err != nil {
-			market.errors = "" // Don't pollute the screen
-		}
-	}()
-
+// Fetch downloads the JSON quote response from the 'url', parses it, and stores
+// resulting data in market's hashes. If download or data parsing fails Fetch
+// panics so the caller (Market.Fetch) can recover.
+func (provider *YahooMarketProvider) Fetch(market *Market) *Market {
 	client := http.Client{
 		Timeout: 10 * time.Second,
 	}
-	request, err := http.NewRequest("GET", market.url, nil)
+
+	url := provider.url
+	if anyExtendedHours(market) {
+		// Ask Yahoo for preMarketPrice/postMarketPrice too so the formatter
+		// can fall back to them while the relevant exchange is in its
+		// pre/post session.
+		url = strings.Replace(url, `includePrePost=false`, `includePrePost=true`, 1)
+	}
+
+	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -92,7 +61,7 @@ func (market *Market) Fetch() (self *Market) {
 		"Accept-Language": {"en-US,en;q=0.5"},
 		"Connection":      {"keep-alive"},
 		"Content-Type":    {"application/json"},
-		"Cookie":          {market.cookies},
+		"Cookie":          {provider.cookies},
 		"Host":            {"query1.finance.yahoo.com"},
 		"Origin":          {"https://finance.yahoo.com"},
 		"Referer":         {"https://finance.yahoo.com"},
@@ -114,37 +83,56 @@ func (market *Market) Fetch() (self *Market) {
 		panic(err)
 	}
 
-	body = market.isMarketOpen(body)
-	return market.extract(body)
+	return provider.extract(market, body)
 }
 
-// Ok returns two values: 1) boolean indicating whether the error has occurred,
-// and 2) the error text itself.
-func (market *Market) Ok() (bool, string) {
-	return market.errors == ``, market.errors
-}
-
-// -----------------------------------------------------------------------------
-func (market *Market) isMarketOpen(body []byte) []byte {
-	// TBD -- CNN page doesn't seem to have market open/close indicator.
-	return body
+// anyExtendedHours reports whether any exchange Market tracks is currently
+// in its pre- or post-market session.
+func anyExtendedHours(market *Market) bool {
+	for index := range exchangeSessions {
+		if state, _ := market.SessionState(index); state == PreMarket || state == PostMarket {
+			return true
+		}
+	}
+	return false
 }
 
 // -----------------------------------------------------------------------------
 func assign(results []map[string]interface{}, position int, changeAsPercent bool) map[string]string {
+	result := results[position]
 	out := make(map[string]string)
-	out[`change`] = float2Str(results[position]["regularMarketChange"].(float64))
-	out[`latest`] = float2Str(results[position]["regularMarketPrice"].(float64))
+	out[`change`] = float2Str(result["regularMarketChange"].(float64))
+	out[`latest`] = float2Str(result["regularMarketPrice"].(float64))
 	if changeAsPercent {
-		out[`change`] = float2Str(results[position]["regularMarketChangePercent"].(float64)) + `%`
+		out[`change`] = float2Str(result["regularMarketChangePercent"].(float64)) + `%`
 	} else {
-		out[`percent`] = float2Str(results[position]["regularMarketChangePercent"].(float64)) + `%`
+		out[`percent`] = float2Str(result["regularMarketChangePercent"].(float64)) + `%`
+	}
+	if price, ok := extendedHoursPrice(result); ok {
+		// `latest` falls back to the pre/post price so it's never stale
+		// relative to what Yahoo actually has; `extended` flags that it did,
+		// so the formatter can highlight it differently from a regular
+		// session quote.
+		out[`latest`] = float2Str(price)
+		out[`extended`] = `true`
 	}
 	return out
 }
 
+// extendedHoursPrice returns Yahoo's preMarketPrice or postMarketPrice for
+// result, whichever is present.
+func extendedHoursPrice(result map[string]interface{}) (float64, bool) {
+	if price, ok := result[`postMarketPrice`].(float64); ok {
+		return price, true
+	}
+	if price, ok := result[`preMarketPrice`].(float64); ok {
+		return price, true
+	}
+	return 0, false
+}
+
 // -----------------------------------------------------------------------------
-func (market *Market) extract(body []byte) *Market {
+func (provider *YahooMarketProvider) extract(market *Market, body []byte) *Market {
 	d := map[string]map[string][]map[string]interface{}{}
 	err := json.Unmarshal(body, &d)
 	if err != nil {
@@ -0,0 +1,85 @@
+// Copyright (c) 2013-2023 by Michael Dvorkin and contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package mop
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const quotesURL = `https://query1.finance.yahoo.com/v7/finance/quote?crumb=%s&symbols=%s`
+const quotesURLQueryParts = `&range=1d&interval=5m&indicators=close&includeTimestamps=false&includePrePost=false&corsDomain=finance.yahoo.com&.tsrc=finance`
+
+// YahooQuotesProvider implements QuotesProvider against Yahoo Finance's quote
+// endpoint, reusing the same crumb/cookie authentication dance as the market
+// indices backend.
+type YahooQuotesProvider struct {
+	cookies string
+	crumb   string
+}
+
+// NewYahooQuotesProvider returns a YahooQuotesProvider with crumb/cookie
+// authentication already negotiated.
+func NewYahooQuotesProvider() *YahooQuotesProvider {
+	provider := &YahooQuotesProvider{}
+	provider.cookies = fetchCookies()
+	provider.crumb = fetchCrumb(provider.cookies)
+
+	return provider
+}
+
+// Fetch downloads and parses quotes for the given tickers from Yahoo Finance.
+func (provider *YahooQuotesProvider) Fetch(tickers []string) (map[string]map[string]string, error) {
+	if len(tickers) == 0 {
+		return map[string]map[string]string{}, nil
+	}
+
+	url := fmt.Sprintf(quotesURL, provider.crumb, strings.Join(tickers, `,`)) + quotesURLQueryParts
+	client := http.Client{Timeout: 10 * time.Second}
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header = http.Header{
+		"Accept":          {"*/*"},
+		"Accept-Language": {"en-US,en;q=0.5"},
+		"Connection":      {"keep-alive"},
+		"Content-Type":    {"application/json"},
+		"Cookie":          {provider.cookies},
+		"Host":            {"query1.finance.yahoo.com"},
+		"Origin":          {"https://finance.yahoo.com"},
+		"Referer":         {"https://finance.yahoo.com"},
+		"User-Agent":      {userAgent},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	d := map[string]map[string][]map[string]interface{}{}
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]map[string]string, len(tickers))
+	for _, result := range d["quoteResponse"]["result"] {
+		ticker, _ := result["symbol"].(string)
+		out[ticker] = assign([]map[string]interface{}{result}, 0, false)
+	}
+
+	return out, nil
+}